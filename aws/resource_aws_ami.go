@@ -0,0 +1,508 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceAwsAmi() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsAmiCreate,
+		Read:   resourceAwsAmiRead,
+		Update: resourceAwsAmiUpdate,
+		Delete: resourceAwsAmiDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"image_location": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"architecture": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"kernel_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"ramdisk_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"root_device_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"root_snapshot_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"virtualization_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "paravirtual",
+				ForceNew: true,
+			},
+			"sriov_net_support": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"ena_support": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"manage_ebs_snapshots": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
+
+			// AMI registration/copy/creation-from-instance can take 40+
+			// minutes and deletion up to 90, so these are exposed as
+			// schema fields rather than relying on the default Timeouts
+			// block, letting each resource instance tune them to the
+			// size of image it manages.
+			"ami_create_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  40,
+			},
+			"ami_delete_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  90,
+			},
+
+			"ebs_block_device": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"delete_on_termination": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+							ForceNew: true,
+						},
+						"device_name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"encrypted": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+						"iops": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+						"snapshot_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+						"volume_size": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+						"volume_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"ephemeral_block_device": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"device_name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"virtual_name": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsAmiCreate(d *schema.ResourceData, meta interface{}) error {
+	// aws_ami is normally managed by more specific resources (aws_ami_copy,
+	// aws_ami_from_instance, aws_ami_register_from_snapshot) that each build
+	// their own RegisterImageInput/CopyImageInput/CreateImageInput and call through to the
+	// shared Read/Update/Delete below, but direct registration is also
+	// supported for advanced use cases.
+	conn := meta.(*AWSClient).ec2conn
+
+	// architecture has no schema default (aws_ami_register_from_snapshot
+	// inherits it from a source image), so direct registration falls back
+	// to x86_64 here.
+	architecture := "x86_64"
+	if v, ok := d.GetOk("architecture"); ok {
+		architecture = v.(string)
+	}
+
+	req := &ec2.RegisterImageInput{
+		Name:               aws.String(d.Get("name").(string)),
+		Description:        aws.String(d.Get("description").(string)),
+		Architecture:       aws.String(architecture),
+		VirtualizationType: aws.String(d.Get("virtualization_type").(string)),
+		RootDeviceName:     aws.String(d.Get("root_device_name").(string)),
+		BlockDeviceMappings: expandAmiBlockDeviceMappings(
+			d.Get("ebs_block_device").(*schema.Set).List(),
+			d.Get("ephemeral_block_device").(*schema.Set).List(),
+		),
+	}
+
+	if v, ok := d.GetOk("kernel_id"); ok {
+		req.KernelId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("ramdisk_id"); ok {
+		req.RamdiskId = aws.String(v.(string))
+	}
+	if v, ok := d.GetOk("sriov_net_support"); ok {
+		req.SriovNetSupport = aws.String(v.(string))
+	}
+	if v, ok := d.GetOkExists("ena_support"); ok {
+		req.EnaSupport = aws.Bool(v.(bool))
+	}
+
+	res, err := conn.RegisterImage(req)
+	if err != nil {
+		return fmt.Errorf("error registering AMI: %s", err)
+	}
+
+	d.SetId(*res.ImageId)
+	d.Set("manage_ebs_snapshots", true)
+
+	if _, err := waitForAmiState(conn, d.Id(), []string{ec2.ImageStateAvailable}, amiCreateTimeout(d)); err != nil {
+		return err
+	}
+
+	return resourceAwsAmiUpdate(d, meta)
+}
+
+func resourceAwsAmiRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	res, err := conn.DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: []*string{aws.String(d.Id())},
+	})
+	if err != nil {
+		if isAWSErr(err, "InvalidAMIID.NotFound", "") {
+			log.Printf("[WARN] AMI (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading AMI (%s): %s", d.Id(), err)
+	}
+
+	if len(res.Images) == 0 {
+		log.Printf("[WARN] AMI (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	image := res.Images[0]
+	if aws.StringValue(image.State) == ec2.ImageStateDeregistered {
+		log.Printf("[WARN] AMI (%s) deregistered, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", image.Name)
+	d.Set("description", image.Description)
+	d.Set("image_location", image.ImageLocation)
+	d.Set("architecture", image.Architecture)
+	d.Set("kernel_id", image.KernelId)
+	d.Set("ramdisk_id", image.RamdiskId)
+	d.Set("root_device_name", image.RootDeviceName)
+	d.Set("virtualization_type", image.VirtualizationType)
+	d.Set("sriov_net_support", image.SriovNetSupport)
+	d.Set("ena_support", image.EnaSupport)
+
+	for _, bdm := range image.BlockDeviceMappings {
+		if bdm.Ebs != nil && aws.StringValue(bdm.DeviceName) == aws.StringValue(image.RootDeviceName) {
+			d.Set("root_snapshot_id", bdm.Ebs.SnapshotId)
+		}
+	}
+
+	if err := d.Set("tags", tagsToMap(image.Tags)); err != nil {
+		return fmt.Errorf("error setting tags: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsAmiUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	if d.HasChange("description") {
+		_, err := conn.ModifyImageAttribute(&ec2.ModifyImageAttributeInput{
+			ImageId:     aws.String(d.Id()),
+			Description: &ec2.AttributeValue{Value: aws.String(d.Get("description").(string))},
+		})
+		if err != nil {
+			return fmt.Errorf("error updating AMI (%s) description: %s", d.Id(), err)
+		}
+	}
+
+	if err := setTags(conn, d); err != nil {
+		return fmt.Errorf("error updating AMI (%s) tags: %s", d.Id(), err)
+	}
+
+	return resourceAwsAmiRead(d, meta)
+}
+
+func resourceAwsAmiDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	res, err := conn.DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: []*string{aws.String(d.Id())},
+	})
+	if err != nil && !isAWSErr(err, "InvalidAMIID.NotFound", "") {
+		return fmt.Errorf("error describing AMI (%s): %s", d.Id(), err)
+	}
+
+	var snapshotIds []string
+	if err == nil && len(res.Images) > 0 {
+		for _, bdm := range res.Images[0].BlockDeviceMappings {
+			if bdm.Ebs != nil && bdm.Ebs.SnapshotId != nil {
+				snapshotIds = append(snapshotIds, *bdm.Ebs.SnapshotId)
+			}
+		}
+	}
+
+	if _, err := conn.DeregisterImage(&ec2.DeregisterImageInput{
+		ImageId: aws.String(d.Id()),
+	}); err != nil {
+		return fmt.Errorf("error deregistering AMI (%s): %s", d.Id(), err)
+	}
+
+	if _, err := waitForAmiState(conn, d.Id(), []string{"destroyed"}, amiDeleteTimeout(d)); err != nil {
+		return err
+	}
+
+	if !d.Get("manage_ebs_snapshots").(bool) {
+		return nil
+	}
+
+	for _, id := range snapshotIds {
+		_, err := conn.DeleteSnapshot(&ec2.DeleteSnapshotInput{
+			SnapshotId: aws.String(id),
+		})
+		if err != nil && !isAWSErr(err, "InvalidSnapshot.NotFound", "") {
+			return fmt.Errorf("error deleting AMI (%s) snapshot (%s): %s", d.Id(), id, err)
+		}
+	}
+
+	return nil
+}
+
+// amiPendingStates covers the non-terminal states an AMI passes through on
+// its way to either ec2.ImageStateAvailable or deregistration, so callers
+// only need to name the target state(s) they care about. ImageStateFailed
+// and ImageStateInvalid are deliberately excluded: they're terminal, and
+// treating them as "still pending" would poll a failed registration/copy
+// for the full timeout instead of surfacing an UnexpectedStateError right
+// away.
+var amiPendingStates = []string{
+	ec2.ImageStatePending,
+	ec2.ImageStateAvailable,
+}
+
+// waitForAmiState polls DescribeImages until the AMI identified by id
+// reaches one of targetStates, where a "destroyed" target is reached once
+// the AMI is deregistered or no longer returned by the API at all (only
+// when "destroyed" is among targetStates), backed by resource.StateChangeConf so
+// callers get consistent backoff/timeout/logging behavior regardless of
+// whether they're waiting on registration, copy, or deletion.
+func waitForAmiState(conn *ec2.EC2, id string, targetStates []string, timeout time.Duration) (*ec2.Image, error) {
+	log.Printf("[DEBUG] Waiting for AMI %s to reach state %v", id, targetStates)
+
+	pending := make([]string, 0, len(amiPendingStates))
+	for _, s := range amiPendingStates {
+		isTarget := false
+		for _, t := range targetStates {
+			if s == t {
+				isTarget = true
+				break
+			}
+		}
+		if !isTarget {
+			pending = append(pending, s)
+		}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     targetStates,
+		Refresh:    amiStateRefreshFunc(conn, id, targetStates),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	info, err := stateConf.WaitForState()
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for AMI (%s) to reach state %v: %s", id, targetStates, err)
+	}
+
+	// The refresh func returns a "destroyed-placeholder" string (not an
+	// *ec2.Image) once the AMI is gone, so a plain type assertion would
+	// panic for any caller waiting on the "destroyed" target.
+	img, _ := info.(*ec2.Image)
+	return img, nil
+}
+
+func amiCreateTimeout(d *schema.ResourceData) time.Duration {
+	return time.Duration(d.Get("ami_create_timeout").(int)) * time.Minute
+}
+
+func amiDeleteTimeout(d *schema.ResourceData) time.Duration {
+	return time.Duration(d.Get("ami_delete_timeout").(int)) * time.Minute
+}
+
+// amiStateRefreshFunc reports a not-found AMI as the terminal "destroyed"
+// state only when a caller is actually waiting on it (targetStates
+// includes "destroyed"). Waiters polling toward ec2.ImageStateAvailable
+// right after RegisterImage/CopyImage/CreateImage can hit a transient
+// InvalidAMIID.NotFound from eventual consistency; returning (nil, "", nil)
+// for them instead lets StateChangeConf's NotFoundChecks tolerance absorb
+// it rather than failing with an UnexpectedStateError on the first miss.
+func amiStateRefreshFunc(conn *ec2.EC2, id string, targetStates []string) resource.StateRefreshFunc {
+	waitingForDestroy := false
+	for _, t := range targetStates {
+		if t == "destroyed" {
+			waitingForDestroy = true
+			break
+		}
+	}
+
+	notFound := func() (interface{}, string, error) {
+		if waitingForDestroy {
+			return "destroyed-placeholder", "destroyed", nil
+		}
+		return nil, "", nil
+	}
+
+	return func() (interface{}, string, error) {
+		res, err := conn.DescribeImages(&ec2.DescribeImagesInput{
+			ImageIds: []*string{aws.String(id)},
+		})
+		if err != nil {
+			if isAWSErr(err, "InvalidAMIID.NotFound", "") {
+				return notFound()
+			}
+			return nil, "", err
+		}
+
+		if len(res.Images) == 0 {
+			return notFound()
+		}
+
+		image := res.Images[0]
+		if aws.StringValue(image.State) == ec2.ImageStateDeregistered {
+			return notFound()
+		}
+
+		return image, aws.StringValue(image.State), nil
+	}
+}
+
+func expandAmiBlockDeviceMappings(ebsBlockDevices, ephemeralBlockDevices []interface{}) []*ec2.BlockDeviceMapping {
+	var mappings []*ec2.BlockDeviceMapping
+
+	for _, v := range ebsBlockDevices {
+		bd := v.(map[string]interface{})
+		mapping := &ec2.BlockDeviceMapping{
+			DeviceName: aws.String(bd["device_name"].(string)),
+			Ebs: &ec2.EbsBlockDevice{
+				DeleteOnTermination: aws.Bool(bd["delete_on_termination"].(bool)),
+			},
+		}
+
+		if v, ok := bd["snapshot_id"]; ok && v.(string) != "" {
+			mapping.Ebs.SnapshotId = aws.String(v.(string))
+		}
+		if v, ok := bd["volume_type"]; ok && v.(string) != "" {
+			mapping.Ebs.VolumeType = aws.String(v.(string))
+		}
+		if v, ok := bd["volume_size"]; ok && v.(int) != 0 {
+			mapping.Ebs.VolumeSize = aws.Int64(int64(v.(int)))
+		}
+		if v, ok := bd["iops"]; ok && v.(int) != 0 {
+			mapping.Ebs.Iops = aws.Int64(int64(v.(int)))
+		}
+		if v, ok := bd["encrypted"]; ok {
+			mapping.Ebs.Encrypted = aws.Bool(v.(bool))
+		}
+
+		mappings = append(mappings, mapping)
+	}
+
+	for _, v := range ephemeralBlockDevices {
+		bd := v.(map[string]interface{})
+		mappings = append(mappings, &ec2.BlockDeviceMapping{
+			DeviceName:  aws.String(bd["device_name"].(string)),
+			VirtualName: aws.String(bd["virtual_name"].(string)),
+		})
+	}
+
+	return mappings
+}
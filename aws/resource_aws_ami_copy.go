@@ -0,0 +1,90 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceAwsAmiCopy() *schema.Resource {
+	// Inherit all of the common fields from the base AMI resource and add
+	// fields specific to the copy operation.
+	resource := resourceAwsAmi()
+	resource.Create = resourceAwsAmiCopyCreate
+
+	resource.Schema["source_ami_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	// This is the region the source_ami_id lives in, always passed as
+	// CopyImageInput.SourceRegion; the copy is cross-region when it differs
+	// from the provider's configured region. Named source_ami_region (pairing with
+	// source_ami_id) rather than source_region to avoid two fields for one
+	// concept.
+	resource.Schema["source_ami_region"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	resource.Schema["encrypted"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		ForceNew: true,
+	}
+
+	resource.Schema["kms_key_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		RequiredWith: []string{"encrypted"},
+	}
+
+	return resource
+}
+
+func resourceAwsAmiCopyCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	// CopyImage is issued against the destination region's EC2 client;
+	// SourceRegion tells it where source_ami_id lives, which may or may not
+	// be the provider's configured region.
+	req := &ec2.CopyImageInput{
+		Name:          aws.String(d.Get("name").(string)),
+		Description:   aws.String(d.Get("description").(string)),
+		SourceImageId: aws.String(d.Get("source_ami_id").(string)),
+		SourceRegion:  aws.String(d.Get("source_ami_region").(string)),
+		ClientToken:   aws.String(resource.UniqueId()),
+	}
+
+	if v, ok := d.GetOk("encrypted"); ok {
+		req.Encrypted = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("kms_key_id"); ok {
+		req.KmsKeyId = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] AMI copy request: %s", req)
+	res, err := conn.CopyImage(req)
+	if err != nil {
+		return fmt.Errorf("error copying AMI: %s", err)
+	}
+
+	id := *res.ImageId
+	d.SetId(id)
+	d.Set("manage_ebs_snapshots", true)
+
+	if _, err := waitForAmiState(conn, id, []string{ec2.ImageStateAvailable}, amiCreateTimeout(d)); err != nil {
+		return err
+	}
+
+	return resourceAwsAmiUpdate(d, meta)
+}
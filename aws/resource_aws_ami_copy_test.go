@@ -0,0 +1,267 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccAWSAMICopy_basic(t *testing.T) {
+	var ami ec2.Image
+	resourceName := "aws_ami_copy.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckAmiDestroy,
+			testAccCheckAmiSnapshotsDeleted(&ami),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAmiCopyConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAmiExists(resourceName, &ami),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttrSet(resourceName, "source_ami_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAMICopy_snapshotSize(t *testing.T) {
+	var ami ec2.Image
+	var bd ec2.BlockDeviceMapping
+	resourceName := "aws_ami_copy.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	expectedDevice := &ec2.EbsBlockDevice{
+		DeleteOnTermination: aws.Bool(true),
+		VolumeSize:          aws.Int64(20),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckAmiDestroy,
+			testAccCheckAmiSnapshotsDeleted(&ami),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAmiCopyConfig_snapshotSize(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAmiExists(resourceName, &ami),
+					testAccCheckAmiBlockDevice(&ami, &bd, "/dev/sda1"),
+					testAccCheckAmiEbsBlockDevice(&bd, expectedDevice),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAMICopy_encryptedWithKMS(t *testing.T) {
+	var ami ec2.Image
+	resourceName := "aws_ami_copy.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAmiDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAmiCopyConfig_encryptedWithKMS(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAmiExists(resourceName, &ami),
+					resource.TestCheckResourceAttr(resourceName, "encrypted", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "kms_key_id", "aws_kms_key.test", "arn"),
+					testAccCheckAmiCopyEncryptedWithKms(&ami, "aws_kms_key.test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAmiCopyEncryptedWithKms(ami *ec2.Image, kmsResourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[kmsResourceName]
+		if !ok {
+			return fmt.Errorf("KMS key not found: %s", kmsResourceName)
+		}
+		kmsKeyArn := rs.Primary.Attributes["arn"]
+
+		for _, bd := range ami.BlockDeviceMappings {
+			if bd.Ebs == nil {
+				continue
+			}
+			if bd.Ebs.Encrypted == nil || !*bd.Ebs.Encrypted {
+				return fmt.Errorf("expected block device %s to be encrypted", *bd.DeviceName)
+			}
+			if bd.Ebs.KmsKeyId == nil || *bd.Ebs.KmsKeyId != kmsKeyArn {
+				return fmt.Errorf("expected block device %s KmsKeyId %s, got %s", *bd.DeviceName, kmsKeyArn, aws.StringValue(bd.Ebs.KmsKeyId))
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccAmiCopyConfig_encryptedWithKMS(rName string) string {
+	// Both provider aliases are pinned to fixed, distinct regions (rather
+	// than leaving the destination on the suite's default provider) so the
+	// cross-region CopyImage path is actually exercised regardless of what
+	// the default test region happens to be.
+	return fmt.Sprintf(`
+provider "aws" {
+  alias  = "source"
+  region = "us-east-1"
+}
+
+provider "aws" {
+  alias  = "destination"
+  region = "us-west-2"
+}
+
+data "aws_availability_zones" "available" {
+  provider = aws.source
+}
+
+resource "aws_kms_key" "test" {
+  provider    = aws.destination
+  description = %[1]q
+}
+
+resource "aws_ebs_volume" "source" {
+  provider          = aws.source
+  availability_zone = data.aws_availability_zones.available.names[0]
+  size              = 8
+
+  tags = {
+    Name = "testAccAmiCopyConfig_encryptedWithKMS-source"
+  }
+}
+
+resource "aws_ebs_snapshot" "source" {
+  provider  = aws.source
+  volume_id = aws_ebs_volume.source.id
+
+  tags = {
+    Name = "testAccAmiCopyConfig_encryptedWithKMS-source"
+  }
+}
+
+resource "aws_ami" "source" {
+  provider            = aws.source
+  name                = "%[1]s-source"
+  root_device_name    = "/dev/sda1"
+  virtualization_type = "hvm"
+
+  ebs_block_device {
+    device_name = "/dev/sda1"
+    snapshot_id = aws_ebs_snapshot.source.id
+  }
+}
+
+resource "aws_ami_copy" "test" {
+  provider          = aws.destination
+  name              = %[1]q
+  source_ami_id     = aws_ami.source.id
+  source_ami_region = "us-east-1"
+  encrypted         = true
+  kms_key_id        = aws_kms_key.test.arn
+}
+`, rName)
+}
+
+func testAccAmiCopyConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_availability_zones" "available" {}
+
+resource "aws_ebs_volume" "source" {
+  availability_zone = data.aws_availability_zones.available.names[0]
+  size              = 8
+
+  tags = {
+    Name = "testAccAmiCopyConfig_basic-source"
+  }
+}
+
+resource "aws_ebs_snapshot" "source" {
+  volume_id = aws_ebs_volume.source.id
+
+  tags = {
+    Name = "testAccAmiCopyConfig_basic-source"
+  }
+}
+
+resource "aws_ami" "source" {
+  name                = "%[1]s-source"
+  root_device_name    = "/dev/sda1"
+  virtualization_type = "hvm"
+
+  ebs_block_device {
+    device_name = "/dev/sda1"
+    snapshot_id = aws_ebs_snapshot.source.id
+  }
+}
+
+resource "aws_ami_copy" "test" {
+  name              = %[1]q
+  source_ami_id     = aws_ami.source.id
+  source_ami_region = data.aws_region.current.name
+}
+
+data "aws_region" "current" {}
+`, rName)
+}
+
+func testAccAmiCopyConfig_snapshotSize(rName string) string {
+	return fmt.Sprintf(`
+data "aws_availability_zones" "available" {}
+
+resource "aws_ebs_volume" "source" {
+  availability_zone = data.aws_availability_zones.available.names[0]
+  size              = 20
+
+  tags = {
+    Name = "testAccAmiCopyConfig_snapshotSize-source"
+  }
+}
+
+resource "aws_ebs_snapshot" "source" {
+  volume_id = aws_ebs_volume.source.id
+
+  tags = {
+    Name = "testAccAmiCopyConfig_snapshotSize-source"
+  }
+}
+
+resource "aws_ami" "source" {
+  name                = "%[1]s-source"
+  root_device_name    = "/dev/sda1"
+  virtualization_type = "hvm"
+
+  ebs_block_device {
+    device_name = "/dev/sda1"
+    snapshot_id = aws_ebs_snapshot.source.id
+  }
+}
+
+resource "aws_ami_copy" "test" {
+  name              = %[1]q
+  source_ami_id     = aws_ami.source.id
+  source_ami_region = data.aws_region.current.name
+}
+
+data "aws_region" "current" {}
+`, rName)
+}
@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceAwsAmiFromInstance() *schema.Resource {
+	resource := resourceAwsAmi()
+	resource.Create = resourceAwsAmiFromInstanceCreate
+
+	resource.Schema["source_instance_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	resource.Schema["snapshot_without_reboot"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		ForceNew: true,
+	}
+
+	return resource
+}
+
+func resourceAwsAmiFromInstanceCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	req := &ec2.CreateImageInput{
+		Name:        aws.String(d.Get("name").(string)),
+		Description: aws.String(d.Get("description").(string)),
+		InstanceId:  aws.String(d.Get("source_instance_id").(string)),
+		NoReboot:    aws.Bool(d.Get("snapshot_without_reboot").(bool)),
+	}
+
+	if v, ok := d.GetOk("ebs_block_device"); ok && len(v.(*schema.Set).List()) > 0 {
+		req.BlockDeviceMappings = expandAmiBlockDeviceMappings(
+			d.Get("ebs_block_device").(*schema.Set).List(),
+			d.Get("ephemeral_block_device").(*schema.Set).List(),
+		)
+	}
+
+	log.Printf("[DEBUG] AMI create-from-instance request: %s", req)
+	res, err := conn.CreateImage(req)
+	if err != nil {
+		return fmt.Errorf("error creating AMI from instance: %s", err)
+	}
+
+	d.SetId(*res.ImageId)
+	d.Set("manage_ebs_snapshots", true)
+
+	if _, err := waitForAmiState(conn, d.Id(), []string{ec2.ImageStateAvailable}, amiCreateTimeout(d)); err != nil {
+		return err
+	}
+
+	return resourceAwsAmiUpdate(d, meta)
+}
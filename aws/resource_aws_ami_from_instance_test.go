@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccAWSAMIFromInstance_basic(t *testing.T) {
+	var ami ec2.Image
+	resourceName := "aws_ami_from_instance.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckAmiDestroy,
+			testAccCheckAmiSnapshotsDeleted(&ami),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAmiFromInstanceConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAmiExists(resourceName, &ami),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttrSet(resourceName, "source_instance_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAMIFromInstance_snapshotSize(t *testing.T) {
+	var ami ec2.Image
+	var bd ec2.BlockDeviceMapping
+	resourceName := "aws_ami_from_instance.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	expectedDevice := &ec2.EbsBlockDevice{
+		DeleteOnTermination: aws.Bool(true),
+		VolumeSize:          aws.Int64(20),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckAmiDestroy,
+			testAccCheckAmiSnapshotsDeleted(&ami),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAmiFromInstanceConfig_snapshotSize(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAmiExists(resourceName, &ami),
+					testAccCheckAmiBlockDevice(&ami, &bd, "/dev/sda1"),
+					testAccCheckAmiEbsBlockDevice(&bd, expectedDevice),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+				),
+			},
+		},
+	})
+}
+
+func testAccAmiFromInstanceConfig_basic(rName string) string {
+	return testAccLatestAmazonLinuxHvmEbsAmiConfig() + fmt.Sprintf(`
+resource "aws_instance" "test" {
+  ami           = data.aws_ami.amzn-ami-minimal-hvm-ebs.id
+  instance_type = "t2.micro"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_ami_from_instance" "test" {
+  name               = %[1]q
+  source_instance_id = aws_instance.test.id
+}
+`, rName)
+}
+
+func testAccAmiFromInstanceConfig_snapshotSize(rName string) string {
+	return testAccLatestAmazonLinuxHvmEbsAmiConfig() + fmt.Sprintf(`
+resource "aws_instance" "test" {
+  ami           = data.aws_ami.amzn-ami-minimal-hvm-ebs.id
+  instance_type = "t2.micro"
+
+  root_block_device {
+    volume_size = 20
+  }
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_ami_from_instance" "test" {
+  name                = %[1]q
+  source_instance_id  = aws_instance.test.id
+  snapshot_without_reboot = true
+}
+`, rName)
+}
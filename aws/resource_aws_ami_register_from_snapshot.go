@@ -0,0 +1,143 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// resourceAwsAmiRegisterFromSnapshot mirrors the "reuse existing image"
+// builder mode used by chroot-based AMI builders: rather than assembling a
+// new EBS volume and snapshot, it registers an image directly from a
+// snapshot that already exists, optionally inheriting kernel/ramdisk/
+// architecture/sriov/block device settings from another AMI.
+func resourceAwsAmiRegisterFromSnapshot() *schema.Resource {
+	resource := resourceAwsAmi()
+	resource.Create = resourceAwsAmiRegisterFromSnapshotCreate
+
+	resource.Schema["snapshot_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	resource.Schema["source_image_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+	}
+
+	return resource
+}
+
+func resourceAwsAmiRegisterFromSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).ec2conn
+
+	req, err := buildRegisterOptsFromExistingImage(d, conn)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] AMI register-from-snapshot request: %s", req)
+	res, err := conn.RegisterImage(req)
+	if err != nil {
+		return fmt.Errorf("error registering AMI from snapshot: %s", err)
+	}
+
+	d.SetId(*res.ImageId)
+	d.Set("manage_ebs_snapshots", false)
+
+	if _, err := waitForAmiState(conn, d.Id(), []string{ec2.ImageStateAvailable}, amiCreateTimeout(d)); err != nil {
+		return err
+	}
+
+	return resourceAwsAmiUpdate(d, meta)
+}
+
+// buildRegisterOptsFromExistingImage assembles a RegisterImageInput for the
+// given snapshot, inheriting kernel_id, ramdisk_id, architecture,
+// sriov_net_support and block device mappings from source_image_id when it
+// is set, with any value explicitly configured in the schema taking
+// precedence over the inherited one.
+func buildRegisterOptsFromExistingImage(d *schema.ResourceData, conn *ec2.EC2) (*ec2.RegisterImageInput, error) {
+	req := &ec2.RegisterImageInput{
+		Name:               aws.String(d.Get("name").(string)),
+		Description:        aws.String(d.Get("description").(string)),
+		VirtualizationType: aws.String(d.Get("virtualization_type").(string)),
+		RootDeviceName:     aws.String(d.Get("root_device_name").(string)),
+	}
+
+	rootSnapshotId := d.Get("snapshot_id").(string)
+	rootDeviceName := d.Get("root_device_name").(string)
+
+	var source *ec2.Image
+	if v, ok := d.GetOk("source_image_id"); ok {
+		res, err := conn.DescribeImages(&ec2.DescribeImagesInput{
+			ImageIds: []*string{aws.String(v.(string))},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error describing source AMI (%s): %s", v.(string), err)
+		}
+		if len(res.Images) == 0 {
+			return nil, fmt.Errorf("source AMI (%s) not found", v.(string))
+		}
+		source = res.Images[0]
+	}
+
+	req.KernelId = stringOrInherited(d, "kernel_id", source, func(i *ec2.Image) *string { return i.KernelId })
+	req.RamdiskId = stringOrInherited(d, "ramdisk_id", source, func(i *ec2.Image) *string { return i.RamdiskId })
+	req.SriovNetSupport = stringOrInherited(d, "sriov_net_support", source, func(i *ec2.Image) *string { return i.SriovNetSupport })
+
+	if v := stringOrInherited(d, "architecture", source, func(i *ec2.Image) *string { return i.Architecture }); v != nil {
+		req.Architecture = v
+	}
+
+	if v, ok := d.GetOk("ebs_block_device"); ok && len(v.(*schema.Set).List()) > 0 {
+		req.BlockDeviceMappings = expandAmiBlockDeviceMappings(
+			d.Get("ebs_block_device").(*schema.Set).List(),
+			d.Get("ephemeral_block_device").(*schema.Set).List(),
+		)
+	} else if source != nil {
+		req.BlockDeviceMappings = source.BlockDeviceMappings
+	}
+
+	// Override (or, if there is no mapping for root_device_name, add) the
+	// root device mapping to point at the supplied snapshot.
+	found := false
+	for _, bdm := range req.BlockDeviceMappings {
+		if aws.StringValue(bdm.DeviceName) == rootDeviceName && bdm.Ebs != nil {
+			bdm.Ebs.SnapshotId = aws.String(rootSnapshotId)
+			found = true
+		}
+	}
+	if !found {
+		req.BlockDeviceMappings = append(req.BlockDeviceMappings, &ec2.BlockDeviceMapping{
+			DeviceName: aws.String(rootDeviceName),
+			Ebs: &ec2.EbsBlockDevice{
+				SnapshotId:          aws.String(rootSnapshotId),
+				DeleteOnTermination: aws.Bool(true),
+			},
+		})
+	}
+
+	if v, ok := d.GetOkExists("ena_support"); ok {
+		req.EnaSupport = aws.Bool(v.(bool))
+	} else if source != nil {
+		req.EnaSupport = source.EnaSupport
+	}
+
+	return req, nil
+}
+
+func stringOrInherited(d *schema.ResourceData, key string, source *ec2.Image, from func(*ec2.Image) *string) *string {
+	if v, ok := d.GetOk(key); ok {
+		return aws.String(v.(string))
+	}
+	if source != nil {
+		return from(source)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccAWSAMIRegisterFromSnapshot_basic(t *testing.T) {
+	var ami ec2.Image
+	resourceName := "aws_ami_register_from_snapshot.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAmiDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAmiRegisterFromSnapshotConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAmiExists(resourceName, &ami),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "virtualization_type", "hvm"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSAMIRegisterFromSnapshot_inheritsFromSourceImage(t *testing.T) {
+	var source, ami ec2.Image
+	sourceResourceName := "aws_ami.source"
+	resourceName := "aws_ami_register_from_snapshot.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAmiDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAmiRegisterFromSnapshotConfig_inherited(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAmiExists(sourceResourceName, &source),
+					testAccCheckAmiExists(resourceName, &ami),
+					resource.TestCheckResourceAttrPair(resourceName, "architecture", sourceResourceName, "architecture"),
+					resource.TestCheckResourceAttrPair(resourceName, "sriov_net_support", sourceResourceName, "sriov_net_support"),
+					resource.TestCheckResourceAttrPair(resourceName, "ena_support", sourceResourceName, "ena_support"),
+				),
+			},
+			{
+				Config: testAccAmiRegisterFromSnapshotConfig_override(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAmiExists(resourceName, &ami),
+					resource.TestCheckResourceAttr(resourceName, "sriov_net_support", "simple"),
+					resource.TestCheckResourceAttr(resourceName, "ena_support", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAmiRegisterFromSnapshotConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+data "aws_availability_zones" "available" {}
+
+resource "aws_ebs_volume" "test" {
+  availability_zone = data.aws_availability_zones.available.names[0]
+  size              = 8
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_ebs_snapshot" "test" {
+  volume_id = aws_ebs_volume.test.id
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_ami_register_from_snapshot" "test" {
+  name                = %[1]q
+  root_device_name    = "/dev/sda1"
+  virtualization_type = "hvm"
+  snapshot_id         = aws_ebs_snapshot.test.id
+}
+`, rName)
+}
+
+func testAccAmiRegisterFromSnapshotConfig_inherited(rName string) string {
+	return fmt.Sprintf(`
+data "aws_availability_zones" "available" {}
+
+resource "aws_ebs_volume" "source" {
+  availability_zone = data.aws_availability_zones.available.names[0]
+  size              = 8
+
+  tags = {
+    Name = "%[1]s-source"
+  }
+}
+
+resource "aws_ebs_snapshot" "source" {
+  volume_id = aws_ebs_volume.source.id
+
+  tags = {
+    Name = "%[1]s-source"
+  }
+}
+
+resource "aws_ami" "source" {
+  name                = "%[1]s-source"
+  root_device_name    = "/dev/sda1"
+  virtualization_type = "hvm"
+  sriov_net_support   = "simple"
+  ena_support         = true
+
+  ebs_block_device {
+    device_name = "/dev/sda1"
+    snapshot_id = aws_ebs_snapshot.source.id
+  }
+}
+
+resource "aws_ebs_snapshot" "test" {
+  volume_id = aws_ebs_volume.source.id
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_ami_register_from_snapshot" "test" {
+  name             = %[1]q
+  root_device_name = "/dev/sda1"
+  source_image_id  = aws_ami.source.id
+  snapshot_id      = aws_ebs_snapshot.test.id
+}
+`, rName)
+}
+
+func testAccAmiRegisterFromSnapshotConfig_override(rName string) string {
+	return fmt.Sprintf(`
+data "aws_availability_zones" "available" {}
+
+resource "aws_ebs_volume" "source" {
+  availability_zone = data.aws_availability_zones.available.names[0]
+  size              = 8
+
+  tags = {
+    Name = "%[1]s-source"
+  }
+}
+
+resource "aws_ebs_snapshot" "source" {
+  volume_id = aws_ebs_volume.source.id
+
+  tags = {
+    Name = "%[1]s-source"
+  }
+}
+
+resource "aws_ami" "source" {
+  name                = "%[1]s-source"
+  root_device_name    = "/dev/sda1"
+  virtualization_type = "hvm"
+  sriov_net_support   = "simple"
+  ena_support         = true
+
+  ebs_block_device {
+    device_name = "/dev/sda1"
+    snapshot_id = aws_ebs_snapshot.source.id
+  }
+}
+
+resource "aws_ebs_snapshot" "test" {
+  volume_id = aws_ebs_volume.source.id
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_ami_register_from_snapshot" "test" {
+  name              = %[1]q
+  root_device_name  = "/dev/sda1"
+  source_image_id   = aws_ami.source.id
+  snapshot_id       = aws_ebs_snapshot.test.id
+  sriov_net_support = "simple"
+  ena_support       = false
+}
+`, rName)
+}
@@ -4,11 +4,11 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -40,6 +40,8 @@ func TestAccAWSAMI_basic(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"manage_ebs_snapshots",
+					"ami_create_timeout",
+					"ami_delete_timeout",
 				},
 			},
 		},
@@ -81,12 +83,36 @@ func TestAccAWSAMI_snapshotSize(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"manage_ebs_snapshots",
+					"ami_create_timeout",
+					"ami_delete_timeout",
 				},
 			},
 		},
 	})
 }
 
+func TestAccAWSAMI_customTimeouts(t *testing.T) {
+	var ami ec2.Image
+	resourceName := "aws_ami.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAmiDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAmiConfig_customTimeouts(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAmiExists(resourceName, &ami),
+					resource.TestCheckResourceAttr(resourceName, "ami_create_timeout", "60"),
+					resource.TestCheckResourceAttr(resourceName, "ami_delete_timeout", "120"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckAmiDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).ec2conn
 
@@ -117,6 +143,34 @@ func testAccCheckAmiDestroy(s *terraform.State) error {
 	return nil
 }
 
+// testAccCheckAmiSnapshotsDeleted asserts that every EBS snapshot backing
+// ami's block device mappings has been deleted. ami must have been
+// populated by testAccCheckAmiExists during an earlier test step, before
+// the resource (and its manage_ebs_snapshots cleanup) was destroyed.
+func testAccCheckAmiSnapshotsDeleted(ami *ec2.Image) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).ec2conn
+
+		for _, bdm := range ami.BlockDeviceMappings {
+			if bdm.Ebs == nil || bdm.Ebs.SnapshotId == nil {
+				continue
+			}
+
+			_, err := conn.DescribeSnapshots(&ec2.DescribeSnapshotsInput{
+				SnapshotIds: []*string{bdm.Ebs.SnapshotId},
+			})
+			if err == nil {
+				return fmt.Errorf("snapshot %s still exists", *bdm.Ebs.SnapshotId)
+			}
+			if !isAWSErr(err, "InvalidSnapshot.NotFound", "") {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAmiExists(n string, ami *ec2.Image) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -130,33 +184,22 @@ func testAccCheckAmiExists(n string, ami *ec2.Image) resource.TestCheckFunc {
 
 		conn := testAccProvider.Meta().(*AWSClient).ec2conn
 
-		var resp *ec2.DescribeImagesOutput
-		err := resource.Retry(1*time.Minute, func() *resource.RetryError {
-			opts := &ec2.DescribeImagesInput{
-				ImageIds: []*string{aws.String(rs.Primary.ID)},
-			}
-			var err error
-			resp, err = conn.DescribeImages(opts)
-			if err != nil {
-				// This can be just eventual consistency
-				awsErr, ok := err.(awserr.Error)
-				if ok && awsErr.Code() == "InvalidAMIID.NotFound" {
-					return resource.RetryableError(err)
-				}
-
-				return resource.NonRetryableError(err)
+		// Registration/copy/creation-from-instance can take 40+ minutes, so
+		// this relies on the same waitForAmiState helper the resources
+		// themselves use rather than a short hard-coded retry window.
+		timeout := 40 * time.Minute
+		if v := rs.Primary.Attributes["ami_create_timeout"]; v != "" {
+			if minutes, err := strconv.Atoi(v); err == nil {
+				timeout = time.Duration(minutes) * time.Minute
 			}
+		}
 
-			return nil
-		})
+		image, err := waitForAmiState(conn, rs.Primary.ID, []string{ec2.ImageStateAvailable}, timeout)
 		if err != nil {
-			return fmt.Errorf("Unable to find AMI after retries: %s", err)
+			return fmt.Errorf("unable to find AMI: %s", err)
 		}
 
-		if len(resp.Images) == 0 {
-			return fmt.Errorf("AMI not found")
-		}
-		*ami = *resp.Images[0]
+		*ami = *image
 		return nil
 	}
 }
@@ -286,3 +329,39 @@ resource "aws_ami" "test" {
 }
 `, rName)
 }
+
+func testAccAmiConfig_customTimeouts(rName string) string {
+	return fmt.Sprintf(`
+data "aws_availability_zones" "available" {}
+
+resource "aws_ebs_volume" "foo" {
+  availability_zone = "${data.aws_availability_zones.available.names[0]}"
+  size              = 8
+
+  tags = {
+    Name = "testAccAmiConfig_customTimeouts"
+  }
+}
+
+resource "aws_ebs_snapshot" "foo" {
+  volume_id = "${aws_ebs_volume.foo.id}"
+
+  tags = {
+    Name = "testAccAmiConfig_customTimeouts"
+  }
+}
+
+resource "aws_ami" "test" {
+  name                = %q
+  root_device_name    = "/dev/sda1"
+  virtualization_type = "hvm"
+  ami_create_timeout  = 60
+  ami_delete_timeout  = 120
+
+  ebs_block_device {
+    device_name = "/dev/sda1"
+    snapshot_id = "${aws_ebs_snapshot.foo.id}"
+  }
+}
+`, rName)
+}